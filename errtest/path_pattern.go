@@ -0,0 +1,193 @@
+// Copyright (c) 2019, AT&T Intellectual Property. All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+//
+// Glob-style matching of management error paths.  Test authors often
+// can't predict the exact value of a list key, an auto-generated ID, or
+// an interface name, so expected paths may use shell-style wildcards
+// (*, ?, [abc]) and {name} placeholder segments instead of requiring an
+// exact match.
+
+package errtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pathHasPatternMetachars reports whether path contains any of the glob
+// or placeholder metacharacters this package understands.  Paths with
+// none of these are matched with a plain exact comparison, preserving
+// backward compatibility with existing tests.
+func pathHasPatternMetachars(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
+}
+
+// matchPathPattern matches path against pattern, where pattern segments
+// may use "*" to match exactly one non-empty path segment, "?" and
+// "[abc]" as single-segment wildcards per path/filepath.Match, "**" to
+// match zero or more whole path segments (greedy, with backtracking), and
+// "{name}" to match exactly one segment and capture its value.
+//
+// On a successful match it returns the segment values captured by any
+// {name} placeholders, keyed by name.  A pattern with no metacharacters
+// is matched by exact string comparison, so existing callers that pass a
+// plain path are unaffected.
+func matchPathPattern(pattern, path string) (map[string]string, bool) {
+	if !pathHasPatternMetachars(pattern) {
+		if pattern == path {
+			return map[string]string{}, true
+		}
+		return nil, false
+	}
+	return matchPathSegments(splitPath(pattern), splitPath(path), map[string]string{})
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func matchPathSegments(
+	patSegs, pathSegs []string,
+	captures map[string]string,
+) (map[string]string, bool) {
+	if len(patSegs) == 0 {
+		if len(pathSegs) == 0 {
+			return captures, true
+		}
+		return nil, false
+	}
+
+	seg := patSegs[0]
+
+	if seg == "**" {
+		// Greedy: consume as many path segments as possible first,
+		// backtracking down to zero if that doesn't lead to an overall
+		// match further down the pattern.
+		for n := len(pathSegs); n >= 0; n-- {
+			if caps, ok := matchPathSegments(
+				patSegs[1:], pathSegs[n:], copyCaptures(captures)); ok {
+				return caps, true
+			}
+		}
+		return nil, false
+	}
+
+	if len(pathSegs) == 0 {
+		return nil, false
+	}
+
+	if name, ok := placeholderName(seg); ok {
+		captures[name] = pathSegs[0]
+		return matchPathSegments(patSegs[1:], pathSegs[1:], captures)
+	}
+
+	if matched, err := filepath.Match(seg, pathSegs[0]); err != nil || !matched {
+		return nil, false
+	}
+	return matchPathSegments(patSegs[1:], pathSegs[1:], captures)
+}
+
+func placeholderName(seg string) (string, bool) {
+	if len(seg) >= 2 && strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+func copyCaptures(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// pathPatternRegexp compiles pattern into a regexp suitable for finding
+// a matching path within free-form text, such as a syslog line.  It is
+// used by CheckMgmtErrorsInLog, which only has the raw log text to
+// search rather than a parsed path, so segment-by-segment backtracking
+// (as used by matchPathPattern) isn't available.  In this context ** is
+// any run of non-whitespace characters between its neighbouring "/"
+// separators, so unlike in matchPathPattern it does not match zero
+// segments when it sits between two other segments.  Wildcard and
+// placeholder segments exclude whitespace as well as "/" so they stop at
+// the end of the path rather than swallowing the rest of the log line.
+func pathPatternRegexp(pattern string) *regexp.Regexp {
+	segs := splitPath(pattern)
+	reSegs := make([]string, 0, len(segs))
+	for _, seg := range segs {
+		switch {
+		case seg == "**":
+			reSegs = append(reSegs, `[^\s]*`)
+		case seg == "*":
+			reSegs = append(reSegs, `[^/\s]*`)
+		default:
+			if name, ok := placeholderName(seg); ok {
+				reSegs = append(reSegs, fmt.Sprintf(`(?P<%s>[^/\s]*)`, name))
+				continue
+			}
+			reSegs = append(reSegs, globSegmentToRegexp(seg))
+		}
+	}
+	return regexp.MustCompile(strings.Join(reSegs, "/"))
+}
+
+// globSegmentToRegexp converts a single filepath.Match-style path segment
+// (?, [abc], literal runes) into the equivalent regexp fragment.
+func globSegmentToRegexp(seg string) string {
+	var b strings.Builder
+	for i := 0; i < len(seg); i++ {
+		c := seg[i]
+		switch c {
+		case '?':
+			b.WriteString(`[^/\s]`)
+		case '[':
+			end := strings.IndexByte(seg[i:], ']')
+			if end == -1 {
+				b.WriteString(regexp.QuoteMeta(seg[i:]))
+				return b.String()
+			}
+			b.WriteString(seg[i : i+end+1])
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// logContainsPath reports whether logStr contains a path matching
+// pattern, returning the segment values captured by any {name}
+// placeholders just as matchPathPattern does for CheckMgmtErrors.
+// Patterns with no metacharacters fall back to a plain substring search,
+// matching the historical behaviour of CheckMgmtErrorsInLog.
+func logContainsPath(logStr, pattern string) (map[string]string, bool) {
+	if !pathHasPatternMetachars(pattern) {
+		if strings.Contains(logStr, pattern) {
+			return map[string]string{}, true
+		}
+		return nil, false
+	}
+
+	re := pathPatternRegexp(pattern)
+	m := re.FindStringSubmatch(logStr)
+	if m == nil {
+		return nil, false
+	}
+
+	captures := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		captures[name] = m[i]
+	}
+	return captures, true
+}
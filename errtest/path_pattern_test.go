@@ -0,0 +1,87 @@
+// Copyright (c) 2019, AT&T Intellectual Property. All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package errtest
+
+import "testing"
+
+func TestMatchPathPatternExact(t *testing.T) {
+	caps, ok := matchPathPattern("interfaces/dataplane/dp0s1", "interfaces/dataplane/dp0s1")
+	if !ok {
+		t.Fatalf("expected exact path match to succeed")
+	}
+	if len(caps) != 0 {
+		t.Fatalf("expected no captures for a plain path, got %v", caps)
+	}
+
+	if _, ok := matchPathPattern("interfaces/dataplane/dp0s1", "interfaces/dataplane/dp0s2"); ok {
+		t.Fatalf("expected differing exact paths not to match")
+	}
+}
+
+func TestMatchPathPatternStar(t *testing.T) {
+	if _, ok := matchPathPattern("interfaces/dataplane/*", "interfaces/dataplane/dp0s1"); !ok {
+		t.Fatalf("expected '*' to match a single segment")
+	}
+	if _, ok := matchPathPattern("interfaces/dataplane/*", "interfaces/dataplane/dp0s1/vif"); ok {
+		t.Fatalf("expected '*' not to match more than one segment")
+	}
+	if _, ok := matchPathPattern("interfaces/dataplane/*", "interfaces/dataplane"); ok {
+		t.Fatalf("expected '*' to require a segment to be present")
+	}
+}
+
+func TestMatchPathPatternDoubleStar(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		wantOK  bool
+	}{
+		{"matches several segments", "interfaces/**/dp0s1", "interfaces/dataplane/vif/dp0s1", true},
+		{"matches zero segments", "interfaces/**/dp0s1", "interfaces/dp0s1", true},
+		{"requires trailing segment", "interfaces/**/dp0s1", "interfaces/dataplane", false},
+	}
+	for _, tt := range tests {
+		if _, ok := matchPathPattern(tt.pattern, tt.path); ok != tt.wantOK {
+			t.Errorf("%s: matchPathPattern(%q, %q) ok = %v, want %v",
+				tt.name, tt.pattern, tt.path, ok, tt.wantOK)
+		}
+	}
+}
+
+func TestMatchPathPatternNameCapture(t *testing.T) {
+	caps, ok := matchPathPattern(
+		"interfaces/dataplane/{ifname}/vif/{vifid}",
+		"interfaces/dataplane/dp0s1/vif/10")
+	if !ok {
+		t.Fatalf("expected {name} placeholders to match")
+	}
+	if caps["ifname"] != "dp0s1" || caps["vifid"] != "10" {
+		t.Fatalf("unexpected captures: %v", caps)
+	}
+}
+
+func TestLogContainsPathExact(t *testing.T) {
+	log := "some prefix interfaces/dataplane/dp0s1 some suffix"
+
+	if _, ok := logContainsPath(log, "interfaces/dataplane/dp0s1"); !ok {
+		t.Fatalf("expected exact path to be found in log")
+	}
+	if _, ok := logContainsPath(log, "interfaces/dataplane/dp0s2"); ok {
+		t.Fatalf("expected differing path not to be found in log")
+	}
+}
+
+func TestLogContainsPathNameCapture(t *testing.T) {
+	log := "some prefix interfaces/dataplane/dp0s1 some suffix"
+
+	caps, ok := logContainsPath(log, "interfaces/dataplane/{ifname}")
+	if !ok {
+		t.Fatalf("expected {name} pattern to be found in log")
+	}
+	if caps["ifname"] != "dp0s1" {
+		t.Fatalf("unexpected captures: %v", caps)
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2019, AT&T Intellectual Property. All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+//
+// RESTCONF (RFC 8040 section 7.1) JSON error support.  This mirrors the
+// CLI/RPC wrappers in test_error.go but emits/parses the
+// "ietf-restconf:errors" envelope instead, so tests exercising a
+// RESTCONF north-bound API don't need to duplicate error definitions.
+
+package errtest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// RESTCONF error-type values, per RFC 8040 section 7.1.
+const (
+	RestconfErrorTypeTransport   = "transport"
+	RestconfErrorTypeRPC         = "rpc"
+	RestconfErrorTypeProtocol    = "protocol"
+	RestconfErrorTypeApplication = "application"
+)
+
+type restconfError struct {
+	ErrorType    string            `json:"error-type"`
+	ErrorTag     string            `json:"error-tag"`
+	ErrorAppTag  string            `json:"error-app-tag,omitempty"`
+	ErrorPath    string            `json:"error-path,omitempty"`
+	ErrorMessage string            `json:"error-message,omitempty"`
+	ErrorInfo    map[string]string `json:"error-info,omitempty"`
+}
+
+type restconfErrorDoc struct {
+	Errors struct {
+		Error []restconfError `json:"error"`
+	} `json:"ietf-restconf:errors"`
+}
+
+// RestconfErrorStrings returns the RESTCONF JSON error document that a
+// TestError built via one of the NewXxxRestconfError constructors below
+// is expected to produce.  As with RpcErrorStrings, te must have been
+// created with the fields this method relies on populated; otherwise the
+// test fails immediately.  error-path is the path elements joined with
+// "/", with the first element qualified by the module name passed to the
+// NewXxxRestconfError constructor, e.g.
+// "/interfaces:interfaces/dataplane/dp0s1".  List keys are rendered as
+// ordinary path elements, not in the RESTCONF "list=key1,key2" form.
+func (te *TestError) RestconfErrorStrings() []string {
+	if te.restconfErrorTag == "" {
+		te.t.Fatalf("Test error message has no 'restconfErrorTag'")
+		return nil
+	}
+
+	pathSlice := getPathSlice(te.t, te.path, "restconf error")
+
+	msg := te.restconfErrorMessage()
+
+	doc := restconfErrorDoc{}
+	doc.Errors.Error = []restconfError{{
+		ErrorType:    te.restconfErrorType,
+		ErrorTag:     te.restconfErrorTag,
+		ErrorAppTag:  te.restconfErrorAppTag,
+		ErrorPath:    restconfPath(te.restconfModule, pathSlice),
+		ErrorMessage: msg,
+		ErrorInfo:    te.restconfErrorInfo,
+	}}
+
+	b, err := json.Marshal(&doc)
+	if err != nil {
+		te.t.Fatalf("Unable to marshal RESTCONF error: %s\n", err)
+		return nil
+	}
+
+	return []string{string(b)}
+}
+
+func (te *TestError) restconfErrorMessage() string {
+	if len(te.rawMsgs) == 0 {
+		return ""
+	}
+	return te.rawMsgs[0]
+}
+
+// restconfPath renders pathSlice as a RESTCONF instance-identifier,
+// qualifying the first path element with module, e.g. for module
+// "interfaces" and pathSlice ["interfaces", "dataplane", "dp0s1"]:
+// "/interfaces:interfaces/dataplane/dp0s1".
+func restconfPath(module string, pathSlice []string) string {
+	if len(pathSlice) == 0 {
+		return ""
+	}
+	segs := make([]string, len(pathSlice))
+	copy(segs, pathSlice)
+	segs[0] = module + ":" + segs[0]
+	return "/" + strings.Join(segs, "/")
+}
+
+// stripRestconfModule strips a "module:" prefix, if present, from the
+// first element of a RESTCONF error-path, and drops the leading "/", so
+// it can be compared against the plain/unqualified, unprefixed paths
+// used everywhere else in this package - expPath in ExpMgmtError never
+// carries either.
+func stripRestconfModule(path string) string {
+	segs := splitPath(path)
+	if len(segs) == 0 {
+		return path
+	}
+	if i := strings.Index(segs[0], ":"); i != -1 {
+		segs[0] = segs[0][i+1:]
+	}
+	return strings.Join(segs, "/")
+}
+
+// restconfInfoMatchesNonFatal tolerates the same "nothing expected,
+// nothing seen" and partial-match cases as checkInfoMatchesNonFatal,
+// but against the error-info object RESTCONF exposes as a JSON map
+// rather than mgmterror.Formattable's single Info value.
+func restconfInfoMatchesNonFatal(info map[string]string, expInfoVal string) bool {
+	if len(info) == 0 {
+		return expInfoVal == ""
+	}
+	if expInfoVal == "" {
+		return false
+	}
+	for _, v := range info {
+		if v == expInfoVal {
+			return true
+		}
+	}
+	return false
+}
+
+// NewExpRestconfError builds an ExpMgmtError for use with
+// CheckRestconfErrors, in the same way NewExpMgmtError does for
+// CheckMgmtErrors, but additionally requiring the error-type/error-tag
+// CheckRestconfErrors must match against the actual RESTCONF error.
+// errType should be one of the RestconfErrorType* constants, and errTag
+// one of the RFC 6241 appendix A / RFC 8040 section 7.1 error-tag
+// strings (e.g. "access-denied").
+func NewExpRestconfError(
+	msgs []string,
+	path, info, errType, errTag string,
+) *ExpMgmtError {
+	e := NewExpMgmtError(msgs, path, info)
+	e.expErrorType = errType
+	e.expErrorTag = errTag
+	return e
+}
+
+// CheckRestconfErrors checks a RESTCONF RFC 8040 section 7.1 JSON error
+// body against the set of expected errors, in the same way
+// CheckMgmtErrors checks a slice of native errors: every error present
+// in jsonBody must be expected, and every expected error must be
+// present.  expPath may be a glob/{name} pattern, as per
+// NewExpMgmtErrorPattern.  expMgmtErrs must be built with
+// NewExpRestconfError, so error-type/error-tag are matched exactly
+// alongside path/message/info.
+func CheckRestconfErrors(
+	t *testing.T,
+	expMgmtErrs []*ExpMgmtError,
+	jsonBody []byte,
+) {
+	var doc restconfErrorDoc
+	if err := json.Unmarshal(jsonBody, &doc); err != nil {
+		t.Fatalf("Unable to parse RESTCONF error body: %s\n%s\n", err, jsonBody)
+		return
+	}
+	actualErrs := doc.Errors.Error
+
+	for _, expErr := range expMgmtErrs {
+		if expErr.expErrorTag == "" {
+			t.Fatalf(
+				"Expected RESTCONF error for path %s has no error-tag - "+
+					"build it with NewExpRestconfError\n", expErr.expPath)
+			return
+		}
+	}
+
+	for _, actErr := range actualErrs {
+		found := false
+	loop1:
+		for _, expErr := range expMgmtErrs {
+			if actErr.ErrorType != expErr.expErrorType ||
+				actErr.ErrorTag != expErr.expErrorTag {
+				continue
+			}
+			caps, ok := matchPathPattern(expErr.expPath, stripRestconfModule(actErr.ErrorPath))
+			if !ok {
+				continue
+			}
+			if !restconfInfoMatchesNonFatal(actErr.ErrorInfo, expErr.expInfo) {
+				continue
+			}
+			for _, expMsg := range expErr.expMsgContents {
+				if !strings.Contains(actErr.ErrorMessage, expMsg) {
+					continue loop1
+				}
+			}
+			expErr.captures = caps
+			found = true
+			break
+		}
+		if !found {
+			t.Fatalf(
+				"Found unexpected RESTCONF error:\n\tType:\t%s\n\tTag:\t%s\n"+
+					"\tPath:\t%s\n\tMsg:\t%s\nInfo:\t%v\n",
+				actErr.ErrorType, actErr.ErrorTag, actErr.ErrorPath,
+				actErr.ErrorMessage, actErr.ErrorInfo)
+			return
+		}
+	}
+
+	for _, expErr := range expMgmtErrs {
+		found := false
+	loop2:
+		for _, actErr := range actualErrs {
+			if actErr.ErrorType != expErr.expErrorType ||
+				actErr.ErrorTag != expErr.expErrorTag {
+				continue
+			}
+			caps, ok := matchPathPattern(expErr.expPath, stripRestconfModule(actErr.ErrorPath))
+			if !ok {
+				continue
+			}
+			if !restconfInfoMatchesNonFatal(actErr.ErrorInfo, expErr.expInfo) {
+				continue
+			}
+			for _, expMsg := range expErr.expMsgContents {
+				if !strings.Contains(actErr.ErrorMessage, expMsg) {
+					continue loop2
+				}
+			}
+			expErr.captures = caps
+			found = true
+			break
+		}
+		if !found {
+			t.Fatalf(
+				"RESTCONF error not found:\n\tType:\t%s\n\tTag:\t%s\n"+
+					"\tPath:\t%s\n\tMsgs:\t%v\nInfo:\t%s\n",
+				expErr.expErrorType, expErr.expErrorTag,
+				expErr.expPath, expErr.expMsgContents, expErr.expInfo)
+			return
+		}
+	}
+}
+
+// restconfTag values are the NETCONF/RESTCONF error-tag strings defined
+// by RFC 6241 appendix A / RFC 8040 section 7.1, reproduced here rather
+// than imported so this file doesn't depend on the exact exported names
+// mgmterror's own tag constants happen to use.
+const (
+	restconfTagAccessDenied    = "access-denied"
+	restconfTagInvalidValue    = "invalid-value"
+	restconfTagMissingElement  = "missing-element"
+	restconfTagOperationFailed = "operation-failed"
+)
+
+// withRestconfTag populates the RESTCONF fields RestconfErrorStrings
+// needs on an already-built TestError and returns it, so the
+// NewXxxRestconfError constructors below can each stay a one-liner.
+// module is the YANG module that qualifies the first element of the
+// resulting error-path, e.g. "interfaces".
+func withRestconfTag(te *TestError, module, errType, errTag string) *TestError {
+	te.restconfModule = module
+	te.restconfErrorType = errType
+	te.restconfErrorTag = errTag
+	return te
+}
+
+// WithRestconfAppTag sets the optional error-app-tag RestconfErrorStrings
+// emits, and returns te so it can be chained directly off a
+// NewXxxRestconfError call.
+func (te *TestError) WithRestconfAppTag(appTag string) *TestError {
+	te.restconfErrorAppTag = appTag
+	return te
+}
+
+// WithRestconfInfo sets the optional error-info RestconfErrorStrings
+// emits, and returns te so it can be chained directly off a
+// NewXxxRestconfError call.
+func (te *TestError) WithRestconfInfo(info map[string]string) *TestError {
+	te.restconfErrorInfo = info
+	return te
+}
+
+// NewAccessDeniedRestconfError is NewAccessDeniedError extended to
+// populate the RESTCONF fields RestconfErrorStrings needs, so tests
+// exercising RESTCONF don't need a parallel error definition.
+func NewAccessDeniedRestconfError(
+	t *testing.T,
+	path, module string,
+) *TestError {
+	return withRestconfTag(NewAccessDeniedError(t, path),
+		module, RestconfErrorTypeProtocol, restconfTagAccessDenied)
+}
+
+// NewInvalidTypeRestconfError is NewInvalidTypeError extended to
+// populate the RESTCONF fields RestconfErrorStrings needs.
+func NewInvalidTypeRestconfError(
+	t *testing.T,
+	path, module, typ string,
+) *TestError {
+	return withRestconfTag(NewInvalidTypeError(t, path, typ),
+		module, RestconfErrorTypeApplication, restconfTagInvalidValue)
+}
+
+// NewMissingMandatoryNodeRestconfError is NewMissingMandatoryNodeError
+// extended to populate the RESTCONF fields RestconfErrorStrings needs.
+func NewMissingMandatoryNodeRestconfError(
+	t *testing.T,
+	path, module string,
+) *TestError {
+	return withRestconfTag(NewMissingMandatoryNodeError(t, path),
+		module, RestconfErrorTypeProtocol, restconfTagMissingElement)
+}
+
+// NewSyntaxRestconfError is NewSyntaxError extended to populate the
+// RESTCONF fields RestconfErrorStrings needs.
+func NewSyntaxRestconfError(
+	t *testing.T,
+	path, module, scriptErr string,
+) *TestError {
+	return withRestconfTag(NewSyntaxError(t, path, scriptErr),
+		module, RestconfErrorTypeApplication, restconfTagOperationFailed)
+}
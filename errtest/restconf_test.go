@@ -0,0 +1,139 @@
+// Copyright (c) 2019, AT&T Intellectual Property. All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package errtest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRestconfErrorStringsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		buildErr    func(t *testing.T) *TestError
+		wantType    string
+		wantTag     string
+		wantMsgPart string
+	}{
+		{
+			name: "access denied",
+			buildErr: func(t *testing.T) *TestError {
+				return NewAccessDeniedRestconfError(t, "interfaces/dataplane/dp0s1", "interfaces")
+			},
+			wantType:    RestconfErrorTypeProtocol,
+			wantTag:     "access-denied",
+			wantMsgPart: "authorization failed",
+		},
+		{
+			name: "invalid type",
+			buildErr: func(t *testing.T) *TestError {
+				return NewInvalidTypeRestconfError(t, "interfaces/dataplane/mtu", "interfaces", "u32")
+			},
+			wantType: RestconfErrorTypeApplication,
+			wantTag:  "invalid-value",
+		},
+		{
+			name: "missing mandatory node",
+			buildErr: func(t *testing.T) *TestError {
+				return NewMissingMandatoryNodeRestconfError(t, "interfaces/dataplane/dp0s1/address", "interfaces")
+			},
+			wantType: RestconfErrorTypeProtocol,
+			wantTag:  "missing-element",
+		},
+		{
+			name: "syntax error",
+			buildErr: func(t *testing.T) *TestError {
+				return NewSyntaxRestconfError(t, "interfaces/dataplane/dp0s1", "interfaces", "bad script")
+			},
+			wantType:    RestconfErrorTypeApplication,
+			wantTag:     "operation-failed",
+			wantMsgPart: "bad script",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := tt.buildErr(t)
+
+			strs := te.RestconfErrorStrings()
+			if len(strs) != 1 {
+				t.Fatalf("expected RestconfErrorStrings to return exactly one document, got %d", len(strs))
+			}
+
+			var doc restconfErrorDoc
+			if err := json.Unmarshal([]byte(strs[0]), &doc); err != nil {
+				t.Fatalf("RestconfErrorStrings returned invalid JSON: %s\n%s", err, strs[0])
+			}
+			if len(doc.Errors.Error) != 1 {
+				t.Fatalf("expected exactly one error in the envelope, got %d", len(doc.Errors.Error))
+			}
+
+			got := doc.Errors.Error[0]
+			if got.ErrorType != tt.wantType {
+				t.Errorf("error-type = %q, want %q", got.ErrorType, tt.wantType)
+			}
+			if got.ErrorTag != tt.wantTag {
+				t.Errorf("error-tag = %q, want %q", got.ErrorTag, tt.wantTag)
+			}
+			if tt.wantMsgPart != "" && !strings.Contains(got.ErrorMessage, tt.wantMsgPart) {
+				t.Errorf("error-message = %q, want it to contain %q", got.ErrorMessage, tt.wantMsgPart)
+			}
+		})
+	}
+}
+
+func TestCheckRestconfErrorsMatchesExpected(t *testing.T) {
+	jsonBody := []byte(`{
+		"ietf-restconf:errors": {
+			"error": [{
+				"error-type": "protocol",
+				"error-tag": "access-denied",
+				"error-path": "/interfaces:interfaces/dataplane/dp0s1",
+				"error-message": "Access to the requested protocol operation or data model is denied"
+			}]
+		}
+	}`)
+
+	exp := []*ExpMgmtError{
+		NewExpRestconfError(
+			[]string{"denied"},
+			"interfaces/dataplane/{ifname}",
+			"",
+			RestconfErrorTypeProtocol,
+			"access-denied"),
+	}
+
+	CheckRestconfErrors(t, exp, jsonBody)
+
+	if exp[0].Captures()["ifname"] != "dp0s1" {
+		t.Errorf("expected {ifname} to be captured as dp0s1, got %v", exp[0].Captures())
+	}
+}
+
+func TestCheckRestconfErrorsInfoMatching(t *testing.T) {
+	jsonBody := []byte(`{
+		"ietf-restconf:errors": {
+			"error": [{
+				"error-type": "protocol",
+				"error-tag": "missing-element",
+				"error-path": "/interfaces:interfaces/dataplane/dp0s1/address",
+				"error-message": "Missing mandatory node",
+				"error-info": {"bad-element": "address"}
+			}]
+		}
+	}`)
+
+	exp := []*ExpMgmtError{
+		NewExpRestconfError(
+			[]string{"Missing"},
+			"interfaces/dataplane/dp0s1/address",
+			"address",
+			RestconfErrorTypeProtocol,
+			"missing-element"),
+	}
+
+	CheckRestconfErrors(t, exp, jsonBody)
+}
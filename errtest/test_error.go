@@ -20,16 +20,38 @@ type ExpMgmtError struct {
 	expMsgContents []string
 	expPath        string
 	expInfo        string
+	captures       map[string]string
+
+	// expErrorType/expErrorTag are only checked by CheckRestconfErrors -
+	// see NewExpRestconfError.
+	expErrorType string
+	expErrorTag  string
 }
 
 func NewExpMgmtError(msgs []string, path, info string) *ExpMgmtError {
 	return &ExpMgmtError{
 		expMsgContents: msgs, // Actual error should contain all these.
-		expPath:        path, // Absolute match
+		expPath:        path, // Absolute match, or glob/{name} pattern.
 		expInfo:        info, // May be empty
 	}
 }
 
+// NewExpMgmtErrorPattern is identical to NewExpMgmtError except it makes
+// explicit at the call site that path is a glob pattern (*, **, ?, [abc])
+// or contains {name} placeholder segments, rather than an exact path.
+// Plain paths are matched exactly either way - path matching transparently
+// supports both - so this is purely a readability aid.
+func NewExpMgmtErrorPattern(msgs []string, path, info string) *ExpMgmtError {
+	return NewExpMgmtError(msgs, path, info)
+}
+
+// Captures returns the segment values bound to any {name} placeholders in
+// expPath.  It is nil until this ExpMgmtError has been successfully
+// matched by CheckMgmtErrors, CheckMgmtErrorsInLog or CheckRestconfErrors.
+func (e *ExpMgmtError) Captures() map[string]string {
+	return e.captures
+}
+
 // Rough and ready check that all parts of all warnings appear at some point
 // in the log
 func CheckMgmtErrorsInLog(
@@ -39,9 +61,11 @@ func CheckMgmtErrorsInLog(
 ) {
 	logStr := log.String()
 	for _, expWarn := range expWarns {
-		if !strings.Contains(logStr, expWarn.expPath) {
+		caps, ok := logContainsPath(logStr, expWarn.expPath)
+		if !ok {
 			t.Fatalf("Syslog doesn't contain path: %s\n", expWarn.expPath)
 		}
+		expWarn.captures = caps
 		for _, msg := range expWarn.expMsgContents {
 			if !strings.Contains(logStr, msg) {
 				t.Fatalf("Syslog doesn't contain msg: %s\n", msg)
@@ -69,7 +93,8 @@ func CheckMgmtErrors(
 		found := false
 	loop1:
 		for _, expErr := range expMgmtErrs {
-			if me.GetPath() != expErr.expPath {
+			caps, ok := matchPathPattern(expErr.expPath, me.GetPath())
+			if !ok {
 				continue
 			}
 			if !checkInfoMatchesNonFatal(me, expErr.expInfo) {
@@ -80,6 +105,7 @@ func CheckMgmtErrors(
 					continue loop1
 				}
 			}
+			expErr.captures = caps
 			found = true
 			break
 		}
@@ -98,7 +124,8 @@ func CheckMgmtErrors(
 	loop2:
 		for _, actErr := range actualErrs {
 			me, _ := actErr.(mgmterror.Formattable)
-			if me.GetPath() != expErr.expPath {
+			caps, ok := matchPathPattern(expErr.expPath, me.GetPath())
+			if !ok {
 				continue
 			}
 			if !checkInfoMatchesNonFatal(me, expErr.expInfo) {
@@ -109,6 +136,7 @@ func CheckMgmtErrors(
 					continue loop2
 				}
 			}
+			expErr.captures = caps
 			found = true
 			break
 		}
@@ -200,6 +228,14 @@ type TestError struct {
 	rpcMsgs   []string
 	setMsg    string
 	setSuffix string // used when set error doesn't end with 'is not valid'
+
+	// RESTCONF (RFC 8040) fields, populated by the NewXxxRestconfError
+	// constructors for use by RestconfErrorStrings.
+	restconfModule      string
+	restconfErrorType   string
+	restconfErrorTag    string
+	restconfErrorAppTag string
+	restconfErrorInfo   map[string]string
 }
 
 func (te *TestError) CliErrorStrings() []string {